@@ -12,6 +12,35 @@ import (
 
 var (
 	ErrInvalidOTP = errors.New("invalid OTP provided")
+	// ErrTooManyAttempts is returned by ValidateOTP once a key has racked up
+	// MaxAttempts consecutive mismatches, until LockoutDuration has passed.
+	ErrTooManyAttempts = errors.New("too many invalid OTP attempts; try again later")
+	// ErrOTPReplayed is returned by a TOTP service's ValidateOTP when the
+	// submitted code was already redeemed within the current skew window.
+	ErrOTPReplayed = errors.New("OTP has already been used")
+)
+
+// Mode selects how an OTPService generates and validates codes.
+type Mode string
+
+const (
+	// ModeRandom generates cryptographically random numeric OTPs stored in
+	// a cache, with no relation to any shared secret. This is the default.
+	ModeRandom Mode = "random"
+	// ModeHOTP generates RFC 4226 HMAC-based OTPs from a shared Secret and
+	// a moving-factor Counter.
+	ModeHOTP Mode = "hotp"
+	// ModeTOTP generates RFC 6238 time-based OTPs from a shared Secret and
+	// the current time, interoperating with authenticator apps such as
+	// Google Authenticator and Authy.
+	ModeTOTP Mode = "totp"
+)
+
+// attemptsKeyPrefix and lockoutKeyPrefix namespace the brute-force tracking
+// cache entries away from the OTP entry itself.
+const (
+	attemptsKeyPrefix = "__attempts:"
+	lockoutKeyPrefix  = "__lockout:"
 )
 
 // OTPService defines the interface for OTP (One-Time Password) operations.
@@ -19,30 +48,138 @@ var (
 type OTPService interface {
 	GenerateOTP(secret string) (string, error)
 	ValidateOTP(secret, otp string) (bool, error)
+
+	// Close releases any resources (e.g. a cache's background cleanup
+	// goroutine) held on behalf of this service. Callers that pass their
+	// own Cache into GoOTPServiceConfig and share it elsewhere should not
+	// call Close, since it closes that Cache too.
+	Close() error
+}
+
+// attemptLimiter enforces MaxAttempts/LockoutDuration brute-force
+// protection against a cache-backed key, shared by every OTPService
+// implementation (random, HOTP, TOTP) instead of each reimplementing it.
+type attemptLimiter struct {
+	CacheService    cache.Cache
+	MaxAttempts     int
+	LockoutDuration time.Duration
+}
+
+// isLockedOut reports whether key is currently locked out from a prior run
+// of MaxAttempts consecutive invalid attempts.
+func (l attemptLimiter) isLockedOut(key string) bool {
+	return l.CacheService.Exists(lockoutKeyPrefix + key)
+}
+
+// recordFailedAttempt increments the attempt counter for key (atomically
+// where the cache supports it; see incrementAttempts) and, once MaxAttempts
+// is reached, locks key out for LockoutDuration. It
+// always returns a non-nil error describing the mismatch or lockout.
+func (l attemptLimiter) recordFailedAttempt(key string) error {
+	expiry := time.Now().Add(l.LockoutDuration).Unix()
+
+	count, err := incrementAttempts(l.CacheService, attemptsKeyPrefix+key, expiry)
+	if err != nil {
+		return fmt.Errorf("failed to record failed attempt for secret: %s: %w", key, err)
+	}
+
+	if count >= int64(l.MaxAttempts) {
+		if err := l.CacheService.Set(lockoutKeyPrefix+key, true, expiry); err != nil {
+			return fmt.Errorf("failed to record lockout for secret: %s: %w", key, err)
+		}
+		return ErrTooManyAttempts
+	}
+
+	return fmt.Errorf("OTP does not match for secret: %s", key)
+}
+
+// clearAttempts resets key's attempt counter and any active lockout, called
+// after a successful validation.
+func (l attemptLimiter) clearAttempts(key string) {
+	l.CacheService.Delete(attemptsKeyPrefix + key)
+	l.CacheService.Delete(lockoutKeyPrefix + key)
+}
+
+// Close stops the attempt limiter's underlying cache.
+func (l attemptLimiter) Close() error {
+	return l.CacheService.Close()
+}
+
+// incrementAttempts atomically increments the counter at key when the cache
+// supports it (cache.Incrementer, e.g. the in-memory cache or Redis),
+// avoiding the read-modify-write race a plain Get-then-Set has under
+// concurrent callers. Backends without atomic increment support fall back
+// to Get-then-Set.
+func incrementAttempts(c cache.Cache, key string, expiration int64) (int64, error) {
+	if incr, ok := c.(cache.Incrementer); ok {
+		return incr.Increment(key, 1, expiration)
+	}
+
+	raw, _ := c.Get(key)
+	var count int64
+	cache.Decode(raw, &count)
+	count++
+	if err := c.Set(key, count, expiration); err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 // otpServiceInstance is the concrete implementation of the OTPService interface.
 // It handles OTP generation and validation using a cache service to store and verify OTPs.
 // Fields:
-//   - CacheService: A cache implementation used to store generated OTPs temporarily
+//   - attemptLimiter: brute-force tracking, embedded for its CacheService,
+//     MaxAttempts, and LockoutDuration fields and its isLockedOut/
+//     recordFailedAttempt/clearAttempts/Close methods
 //   - Length: The length of generated OTPs (e.g., 6 for a 6-digit OTP)
+//   - TTL: How long a generated OTP remains valid in the cache
 type otpServiceInstance struct {
-	CacheService cache.Cache
-	Length       int
+	attemptLimiter
+	Length int
+	TTL    time.Duration
 }
 
 // GoOTPServiceConfig defines the configuration options for the OTP service.
 // Fields:
-//   - Cache: The cache implementation to use for storing OTPs
+//   - Cache: The cache implementation to use for storing OTPs. Any
+//     implementation of cache.Cache is accepted, including the backends
+//     under pkg/cache/providers (Redis, Memcached, BadgerDB), so the service
+//     can be scaled horizontally across API nodes sharing a backend.
 //   - Length: The desired length of generated OTPs (e.g., 6 for 6-digit OTPs)
+//   - TTL: How long a generated OTP remains valid in the cache (defaults to
+//     10 minutes).
+//   - EncryptionKey: If set, must be a 32-byte AES-256 key. Cache is wrapped
+//     in a cache.EncryptedCache so OTPs are encrypted at rest and their keys
+//     are HMAC'd before reaching the underlying store.
+//   - MaxAttempts: The number of consecutive invalid OTPs allowed for a key
+//     before it is locked out (defaults to 5).
+//   - LockoutDuration: How long a key stays locked out once MaxAttempts is
+//     reached (defaults to 15 minutes).
+//   - Mode: Selects the OTP algorithm: ModeRandom (default), ModeHOTP, or
+//     ModeTOTP. HOTP and TOTP require Secret.
+//   - Secret: The shared secret for HOTP/TOTP, base32-encoded (RFC 4648, as
+//     used by authenticator apps). Unused in ModeRandom.
+//   - Counter: The initial moving-factor counter for ModeHOTP.
+//   - Period: The time-step, in seconds, for ModeTOTP (defaults to 30).
+//   - Skew: The number of Periods of clock drift ModeTOTP tolerates on
+//     either side of the current step when validating (defaults to 1).
 type GoOTPServiceConfig struct {
-	Cache  cache.Cache
-	Length int
+	Cache           cache.Cache
+	Length          int
+	TTL             time.Duration
+	EncryptionKey   []byte
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	Mode            Mode
+	Secret          string
+	Counter         uint64
+	Period          int
+	Skew            int
 }
 
 // GenerateOTP creates a cryptographically secure random OTP (One-Time Password) for a given key.
 // It generates a random number with the specified length (e.g., 6 digits would be between 100000 and 999999).
-// The generated OTP is stored in the cache with the provided key and expires after 10 minutes.
+// The generated OTP is stored in the cache with the provided key and expires after o.TTL.
 // Parameters:
 //   - key: The unique identifier used to store and later validate the OTP
 //
@@ -69,7 +206,7 @@ func (o otpServiceInstance) GenerateOTP(key string) (string, error) {
 	otp := fmt.Sprintf("%0*d", o.Length, result)
 
 	//store it in cache with key sepcified so that it can be validated later
-	if err := o.CacheService.Set(key, otp, time.Now().Add(10*time.Minute).Unix()); err != nil {
+	if err := o.CacheService.Set(key, otp, time.Now().Add(o.TTL).Unix()); err != nil {
 		return "", fmt.Errorf("failed to store OTP in cache: %v", err)
 	}
 
@@ -78,11 +215,15 @@ func (o otpServiceInstance) GenerateOTP(key string) (string, error) {
 
 // ValidateOTP verifies if the provided OTP matches the one stored in cache for the given key.
 // The OTP is only valid if:
+//   - The key is not currently locked out from too many prior invalid attempts
 //   - It is not empty and matches the configured length
 //   - A corresponding entry exists in the cache for the given key
 //   - The cached OTP matches the provided OTP
 //
-// After successful validation, the OTP is deleted from cache to prevent reuse.
+// After successful validation, the OTP and the attempt counter are deleted from
+// cache to prevent reuse. Each mismatch increments a per-key attempt counter;
+// once it reaches MaxAttempts, the OTP is invalidated and further attempts are
+// refused with ErrTooManyAttempts until LockoutDuration has elapsed.
 //
 // Parameters:
 //   - key: The unique identifier used when the OTP was generated
@@ -90,48 +231,119 @@ func (o otpServiceInstance) GenerateOTP(key string) (string, error) {
 //
 // Returns:
 //   - bool: true if the OTP is valid, false otherwise
-//   - error: ErrInvalidOTP if OTP format is invalid, or other errors explaining validation failure
+//   - error: ErrInvalidOTP if OTP format is invalid, ErrTooManyAttempts if the
+//     key is locked out, or other errors explaining validation failure
 func (o otpServiceInstance) ValidateOTP(key, otp string) (bool, error) {
+	if o.isLockedOut(key) {
+		return false, ErrTooManyAttempts
+	}
+
 	if otp == "" || len(otp) != o.Length {
 		return false, ErrInvalidOTP
 	}
 
-	cachedOTP, exists := o.CacheService.Get(key)
+	var cachedOTP any
+	var exists bool
+	if secure, ok := o.CacheService.(cache.SecureCache); ok {
+		value, err := secure.GetSecure(key)
+		switch {
+		case err == nil:
+			cachedOTP, exists = value, true
+		case errors.Is(err, cache.ErrDecryptionFailed):
+			return false, fmt.Errorf("failed to decrypt OTP for secret: %s: %w", key, err)
+		case errors.Is(err, cache.ErrCacheMiss):
+			exists = false
+		default:
+			return false, err
+		}
+	} else {
+		cachedOTP, exists = o.CacheService.Get(key)
+	}
+
 	if !exists {
+		// A concurrent attempt may have just locked key out and invalidated
+		// its OTP; report the lockout rather than a plain miss.
+		if o.isLockedOut(key) {
+			return false, ErrTooManyAttempts
+		}
 		return false, fmt.Errorf("OTP not found in cache for secret: %s", key)
 	}
 
 	if cachedOTP == nil || cachedOTP.(string) != otp {
-		return false, fmt.Errorf("OTP does not match for secret: %s", key)
+		if err := o.recordFailedAttempt(key); err != nil {
+			if errors.Is(err, ErrTooManyAttempts) {
+				// Invalidate the OTP so it cannot be redeemed even within its own TTL.
+				o.CacheService.Delete(key)
+			}
+			return false, err
+		}
 	}
-	// If OTP matches, delete it from cache to prevent reuse
+	// If OTP matches, delete it and the attempt counter to prevent reuse
 	o.CacheService.Delete(key)
+	o.clearAttempts(key)
 	return true, nil
 }
 
 // NewService creates and returns a new OTP service instance with the provided configuration.
 // If no cache service is specified in the config, it uses a default cache implementation.
 // If no length is specified (or if length < 1), it defaults to 6 digits.
+// If conf.EncryptionKey is set, the cache is wrapped in a cache.EncryptedCache
+// so OTPs are encrypted at rest.
 //
 // Parameters:
 //   - conf: GoOTPServiceConfig containing the cache service and desired OTP length
 //
 // Returns:
 //   - OTPService: An interface implementation for OTP operations
-//   - error: Currently always returns nil, but maintained for future error handling
+//   - error: An error if the encrypted cache could not be initialized, if
+//     conf.Mode is not supported, or if conf.Secret cannot be decoded for
+//     ModeHOTP/ModeTOTP
 func NewService(conf GoOTPServiceConfig) (OTPService, error) {
 	//if no cache service is provided, we will use default cache implementation
 	if conf.Cache == nil {
-		conf.Cache = cache.GetCache()
+		conf.Cache = cache.New(cache.Options{})
 	}
 
 	if conf.Length < 1 {
 		conf.Length = 6 // Default OTP length
 	}
 
-	// Initialization logic can be added here if needed
-	return otpServiceInstance{
-		conf.Cache,
-		conf.Length,
-	}, nil
+	if conf.TTL <= 0 {
+		conf.TTL = 10 * time.Minute // Default OTP validity window
+	}
+
+	if conf.MaxAttempts < 1 {
+		conf.MaxAttempts = 5 // Default brute-force threshold
+	}
+
+	if conf.LockoutDuration <= 0 {
+		conf.LockoutDuration = 15 * time.Minute // Default cool-down window
+	}
+
+	if len(conf.EncryptionKey) > 0 {
+		encrypted, err := cache.NewEncryptedCache(conf.Cache, conf.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize encrypted cache: %w", err)
+		}
+		conf.Cache = encrypted
+	}
+
+	switch conf.Mode {
+	case "", ModeRandom:
+		return otpServiceInstance{
+			attemptLimiter: attemptLimiter{
+				CacheService:    conf.Cache,
+				MaxAttempts:     conf.MaxAttempts,
+				LockoutDuration: conf.LockoutDuration,
+			},
+			Length: conf.Length,
+			TTL:    conf.TTL,
+		}, nil
+	case ModeHOTP:
+		return newHOTPService(conf)
+	case ModeTOTP:
+		return newTOTPService(conf)
+	default:
+		return nil, fmt.Errorf("gootp: unsupported mode %q", conf.Mode)
+	}
 }
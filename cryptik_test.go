@@ -0,0 +1,122 @@
+package gootp
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateOTPThenValidate(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{Length: 6})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+	if len(otp) != 6 {
+		t.Fatalf("GenerateOTP() = %q, want a 6-digit OTP", otp)
+	}
+
+	ok, err := svc.ValidateOTP("user-1", otp)
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() = %v, %v, want true, nil", ok, err)
+	}
+
+	// The OTP is consumed on success; it cannot be redeemed twice.
+	if ok, err := svc.ValidateOTP("user-1", otp); ok || err == nil {
+		t.Fatalf("second ValidateOTP() = %v, %v, want false, non-nil error", ok, err)
+	}
+}
+
+func TestValidateOTPLockout(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{
+		Length:          6,
+		MaxAttempts:     3,
+		LockoutDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.ValidateOTP("user-1", "000000"); err == ErrTooManyAttempts {
+			t.Fatalf("locked out after only %d attempts, want 3", i+1)
+		}
+	}
+
+	if _, err := svc.ValidateOTP("user-1", "000000"); err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() at MaxAttempts = %v, want ErrTooManyAttempts", err)
+	}
+
+	// Even the correct OTP is refused while locked out.
+	if ok, err := svc.ValidateOTP("user-1", otp); ok || err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() with correct code while locked out = %v, %v, want false, ErrTooManyAttempts", ok, err)
+	}
+}
+
+func TestValidateOTPConcurrentAttemptsRespectMaxAttempts(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{
+		Length:          6,
+		MaxAttempts:     5,
+		LockoutDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	if _, err := svc.GenerateOTP("user-1"); err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	wg.Add(attempts)
+	var lockouts int32Counter
+	for i := 0; i < attempts; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := svc.ValidateOTP("user-1", "000000"); err == ErrTooManyAttempts {
+				lockouts.add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// With an atomic counter, exactly attempts-MaxAttempts+1 callers should
+	// observe the lockout (every attempt from the one that hits MaxAttempts
+	// onward); a racy read-modify-write could under- or over-count this.
+	want := int32(attempts - 5 + 1)
+	if got := lockouts.value(); got != want {
+		t.Fatalf("observed %d lockouts across %d concurrent attempts, want exactly %d", got, attempts, want)
+	}
+}
+
+// int32Counter is a tiny helper so the test above doesn't need to import
+// sync/atomic just for one counter.
+type int32Counter struct {
+	mu sync.Mutex
+	n  int32
+}
+
+func (c *int32Counter) add(delta int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n += delta
+}
+
+func (c *int32Counter) value() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
@@ -0,0 +1,131 @@
+package gootp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+// hotpCounterKeyPrefix namespaces the cache entry that tracks each key's
+// current HOTP moving-factor counter.
+const hotpCounterKeyPrefix = "__hotp_counter:"
+
+// hotpCounterTTL bounds how long a HOTP counter is retained between uses.
+// It is deliberately long: the counter is server-side resync state, not a
+// short-lived OTP, and shouldn't expire just because a key goes quiet.
+const hotpCounterTTL = 10 * 365 * 24 * time.Hour
+
+// hotpServiceInstance implements OTPService using RFC 4226 HOTP. The moving
+// factor counter is tracked per key in the cache rather than pinned to the
+// service instance, so one service can serve many secrets/users, and
+// ValidateOTP accepts a code produced independently by an authenticator app
+// or hardware token - without the server ever having called GenerateOTP -
+// by searching a resync window of counters ahead of its last known
+// position, as RFC 4226 section 7.4 recommends.
+type hotpServiceInstance struct {
+	attemptLimiter
+	Secret         []byte
+	Length         int
+	InitialCounter uint64
+	Window         int
+}
+
+// newHOTPService builds an OTPService in ModeHOTP from conf. conf.Secret
+// must be a base32-encoded shared key, and conf.Counter seeds the counter
+// the first time a given key is seen. conf.Skew is reused as the forward
+// resync window ValidateOTP searches (defaults to 1).
+func newHOTPService(conf GoOTPServiceConfig) (OTPService, error) {
+	secret, err := decodeSecret(conf.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	window := conf.Skew
+	if window <= 0 {
+		window = 1
+	}
+
+	return &hotpServiceInstance{
+		attemptLimiter: attemptLimiter{
+			CacheService:    conf.Cache,
+			MaxAttempts:     conf.MaxAttempts,
+			LockoutDuration: conf.LockoutDuration,
+		},
+		Secret:         secret,
+		Length:         conf.Length,
+		InitialCounter: conf.Counter,
+		Window:         window,
+	}, nil
+}
+
+// GenerateOTP computes the HOTP value at key's current counter, without
+// advancing it - so repeated calls return the same code until one is
+// validated, the same way TOTP keeps returning the same code for a whole
+// Period.
+func (h *hotpServiceInstance) GenerateOTP(key string) (string, error) {
+	counter, err := h.loadCounter(key)
+	if err != nil {
+		return "", err
+	}
+	return hotpCode(h.Secret, counter, h.Length), nil
+}
+
+// ValidateOTP searches counters from key's current position up to Window
+// steps ahead for one that produces otp, so a code generated independently
+// by an authenticator app or hardware token - whose own counter may have
+// drifted ahead of the server's - still validates. On a match, the counter
+// is advanced past the one used, so it can't be replayed.
+func (h *hotpServiceInstance) ValidateOTP(key, otp string) (bool, error) {
+	if h.isLockedOut(key) {
+		return false, ErrTooManyAttempts
+	}
+
+	if otp == "" || len(otp) != h.Length {
+		return false, ErrInvalidOTP
+	}
+
+	counter, err := h.loadCounter(key)
+	if err != nil {
+		return false, err
+	}
+
+	for step := 0; step <= h.Window; step++ {
+		candidate := counter + uint64(step)
+		if hotpCode(h.Secret, candidate, h.Length) != otp {
+			continue
+		}
+
+		if err := h.storeCounter(key, candidate+1); err != nil {
+			return false, err
+		}
+		h.clearAttempts(key)
+		return true, nil
+	}
+
+	return false, h.recordFailedAttempt(key)
+}
+
+// loadCounter returns key's current HOTP counter, or InitialCounter if key
+// has never been seen before.
+func (h *hotpServiceInstance) loadCounter(key string) (uint64, error) {
+	raw, exists := h.CacheService.Get(hotpCounterKeyPrefix + key)
+	if !exists {
+		return h.InitialCounter, nil
+	}
+
+	var counter uint64
+	if !cache.Decode(raw, &counter) {
+		return 0, fmt.Errorf("gootp: corrupt HOTP counter for secret: %s", key)
+	}
+	return counter, nil
+}
+
+// storeCounter persists key's new HOTP counter.
+func (h *hotpServiceInstance) storeCounter(key string, counter uint64) error {
+	expiry := time.Now().Add(hotpCounterTTL).Unix()
+	if err := h.CacheService.Set(hotpCounterKeyPrefix+key, counter, expiry); err != nil {
+		return fmt.Errorf("failed to persist HOTP counter for secret: %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package gootp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+// rfc4226Secret is the 20-byte ASCII secret from RFC 4226 Appendix D,
+// base32-encoded since decodeSecret expects the authenticator-app
+// convention.
+var rfc4226Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// rfc4226Vectors are the expected HOTP values for counters 0-9 from RFC
+// 4226 Appendix D.
+var rfc4226Vectors = []string{
+	"755224", "287082", "359152", "969429", "338314",
+	"254676", "287922", "162583", "399871", "520489",
+}
+
+func newTestHOTPService(t *testing.T) OTPService {
+	t.Helper()
+	svc, err := NewService(GoOTPServiceConfig{
+		Mode:   ModeHOTP,
+		Secret: rfc4226Secret,
+		Length: 6,
+		Cache:  cache.New(cache.Options{}),
+		Skew:   3,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestHOTPMatchesRFC4226Vectors(t *testing.T) {
+	for counter, want := range rfc4226Vectors {
+		got := hotpCode(mustDecodeSecret(t), uint64(counter), 6)
+		if got != want {
+			t.Errorf("hotpCode(counter=%d) = %s, want %s", counter, got, want)
+		}
+	}
+}
+
+func mustDecodeSecret(t *testing.T) []byte {
+	t.Helper()
+	secret, err := decodeSecret(rfc4226Secret)
+	if err != nil {
+		t.Fatalf("decodeSecret: %v", err)
+	}
+	return secret
+}
+
+func TestHOTPValidatesIndependentlyGeneratedCode(t *testing.T) {
+	svc := newTestHOTPService(t)
+
+	// A code produced by an independent authenticator app/token for counter
+	// 0 must validate even though this service never called GenerateOTP.
+	ok, err := svc.ValidateOTP("user-1", rfc4226Vectors[0])
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() = %v, %v, want true, nil", ok, err)
+	}
+
+	// The same code must not validate twice.
+	ok, err = svc.ValidateOTP("user-1", rfc4226Vectors[0])
+	if ok {
+		t.Fatalf("ValidateOTP() replayed an already-used code: %v, %v", ok, err)
+	}
+}
+
+func TestHOTPResyncWindow(t *testing.T) {
+	svc := newTestHOTPService(t)
+
+	// Skip ahead as if the device had generated (and the user never
+	// submitted) counters 0 and 1; submit the code for counter 2 first.
+	ok, err := svc.ValidateOTP("user-1", rfc4226Vectors[2])
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() within the resync window = %v, %v, want true, nil", ok, err)
+	}
+
+	// Counter 3 should now validate too (the server resynced past 2).
+	ok, err = svc.ValidateOTP("user-1", rfc4226Vectors[3])
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() for the next counter = %v, %v, want true, nil", ok, err)
+	}
+}
+
+func TestHOTPPerKeyIsolation(t *testing.T) {
+	svc := newTestHOTPService(t)
+
+	// Each key tracks its own counter, so the same counter-0 code validates
+	// independently for two different users.
+	for _, key := range []string{"user-1", "user-2"} {
+		ok, err := svc.ValidateOTP(key, rfc4226Vectors[0])
+		if err != nil || !ok {
+			t.Fatalf("ValidateOTP(%q) = %v, %v, want true, nil", key, ok, err)
+		}
+	}
+}
+
+func TestHOTPGenerateOTPThenValidate(t *testing.T) {
+	svc := newTestHOTPService(t)
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+	if otp != rfc4226Vectors[0] {
+		t.Fatalf("GenerateOTP() = %s, want %s", otp, rfc4226Vectors[0])
+	}
+
+	ok, err := svc.ValidateOTP("user-1", otp)
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() = %v, %v, want true, nil", ok, err)
+	}
+}
+
+func TestHOTPRateLimiting(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{
+		Mode:            ModeHOTP,
+		Secret:          rfc4226Secret,
+		Length:          6,
+		Cache:           cache.New(cache.Options{}),
+		MaxAttempts:     3,
+		LockoutDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.ValidateOTP("user-1", "000000"); err == ErrTooManyAttempts {
+			t.Fatalf("locked out after only %d attempts, want 3", i+1)
+		}
+	}
+
+	// The 3rd consecutive mismatch reaches MaxAttempts and locks the key out.
+	if _, err := svc.ValidateOTP("user-1", "000000"); err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() at MaxAttempts = %v, want ErrTooManyAttempts", err)
+	}
+
+	// A correct code is still refused while locked out.
+	if ok, err := svc.ValidateOTP("user-1", rfc4226Vectors[0]); ok || err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() with correct code while locked out = %v, %v, want false, ErrTooManyAttempts", ok, err)
+	}
+}
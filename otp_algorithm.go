@@ -0,0 +1,46 @@
+package gootp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// decodeSecret base32-decodes a shared secret as used by authenticator apps
+// (RFC 4648, upper-case, optionally unpadded).
+func decodeSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("gootp: secret must not be empty")
+	}
+
+	normalized := strings.TrimRight(strings.ToUpper(strings.TrimSpace(secret)), "=")
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("gootp: failed to base32-decode secret: %w", err)
+	}
+	return decoded, nil
+}
+
+// hotpCode computes the RFC 4226 HOTP value for secret at the given moving
+// factor counter, truncated to length decimal digits via the dynamic
+// truncation described in RFC 4226 section 5.3.
+func hotpCode(secret []byte, counter uint64, length int) string {
+	counterBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(counterBytes, counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counterBytes)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < length; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", length, truncated%mod)
+}
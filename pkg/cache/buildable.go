@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// BuildFunc produces a fresh value to populate the cache on a miss.
+type BuildFunc func(ctx context.Context) (any, error)
+
+// buildableEntry is what BuildableCache actually stores: the built value
+// plus the bookkeeping GetOrBuild needs for stale-while-revalidate.
+type buildableEntry struct {
+	Value      any
+	Expiry     int64 // hard expiry (Unix seconds); entry is unusable past this
+	SoftExpiry int64 // soft expiry (Unix seconds); zero disables stale-while-revalidate
+}
+
+// BuildableCache wraps a Cache with a cache-aside GetOrBuild API: on a miss
+// it invokes a caller-supplied build function, coalescing concurrent
+// callers for the same key through a singleflight.Group so only one build
+// runs per key. It optionally supports stale-while-revalidate, so downstream
+// users of cryptik can cache expensive derivations (per-user secrets, KDF
+// outputs, ...) alongside OTPs without dog-piling the origin.
+type BuildableCache struct {
+	Cache
+	group singleflight.Group
+}
+
+// NewBuildableCache wraps underlying with a GetOrBuild API.
+func NewBuildableCache(underlying Cache) *BuildableCache {
+	return &BuildableCache{Cache: underlying}
+}
+
+// GetOrBuild returns the cached value for key if present, or calls build to
+// populate the cache with a ttl expiry if it is not. Concurrent calls for
+// the same key coalesce onto a single build invocation.
+func (b *BuildableCache) GetOrBuild(ctx context.Context, key string, ttl time.Duration, build BuildFunc) (any, error) {
+	return b.GetOrBuildStale(ctx, key, ttl, 0, build)
+}
+
+// GetOrBuildStale behaves like GetOrBuild, but entries become "stale"
+// staleTTL before their hard expiry. A stale entry is still returned
+// immediately, but also triggers an asynchronous rebuild so the next caller
+// gets a fresh value. A zero staleTTL disables this and behaves like
+// GetOrBuild.
+func (b *BuildableCache) GetOrBuildStale(ctx context.Context, key string, ttl, staleTTL time.Duration, build BuildFunc) (any, error) {
+	if raw, ok := b.Cache.Get(key); ok {
+		var entry buildableEntry
+		if !Decode(raw, &entry) {
+			return raw, nil
+		}
+
+		if entry.SoftExpiry > 0 && time.Now().Unix() >= entry.SoftExpiry {
+			b.revalidateAsync(key, ttl, staleTTL, build)
+		}
+		return entry.Value, nil
+	}
+
+	value, err, _ := b.group.Do(key, func() (any, error) {
+		return b.rebuild(ctx, key, ttl, staleTTL, build)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// rebuild invokes build and stores the result, returning the freshly built
+// value.
+func (b *BuildableCache) rebuild(ctx context.Context, key string, ttl, staleTTL time.Duration, build BuildFunc) (any, error) {
+	value, err := build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := buildableEntry{Value: value, Expiry: now.Add(ttl).Unix()}
+	if staleTTL > 0 && staleTTL < ttl {
+		entry.SoftExpiry = now.Add(ttl - staleTTL).Unix()
+	}
+
+	if err := b.Cache.Set(key, entry, entry.Expiry); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// revalidateAsync kicks off a rebuild for key in the background, coalescing
+// with any rebuild already in flight for the same key. The triggering
+// request's context is not used here since the rebuild must outlive it.
+func (b *BuildableCache) revalidateAsync(key string, ttl, staleTTL time.Duration, build BuildFunc) {
+	b.group.DoChan(key, func() (any, error) {
+		return b.rebuild(context.Background(), key, ttl, staleTTL, build)
+	})
+}
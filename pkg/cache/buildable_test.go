@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBuildableCacheGetOrBuildCachesResult(t *testing.T) {
+	underlying := New(Options{})
+	defer underlying.Close()
+	b := NewBuildableCache(underlying)
+
+	var calls int32
+	build := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := b.GetOrBuild(context.Background(), "k", time.Minute, build)
+		if err != nil || v != "value" {
+			t.Fatalf("GetOrBuild() = %v, %v, want value, nil", v, err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("build was called %d times, want 1", calls)
+	}
+}
+
+func TestBuildableCacheCoalescesConcurrentBuilds(t *testing.T) {
+	underlying := New(Options{})
+	defer underlying.Close()
+	b := NewBuildableCache(underlying)
+
+	var calls int32
+	release := make(chan struct{})
+	build := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	const n = 10
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			v, err := b.GetOrBuild(context.Background(), "k", time.Minute, build)
+			if err != nil || v != "value" {
+				t.Errorf("GetOrBuild() = %v, %v, want value, nil", v, err)
+			}
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("build was called %d times for concurrent callers, want 1", calls)
+	}
+}
+
+func TestBuildableCacheStaleWhileRevalidate(t *testing.T) {
+	underlying := New(Options{})
+	defer underlying.Close()
+	b := NewBuildableCache(underlying)
+
+	var calls int32
+	build := func(ctx context.Context) (any, error) {
+		n := atomic.AddInt32(&calls, 1)
+		return n, nil
+	}
+
+	// SoftExpiry lands 1ms after the entry is built; sleeping past it before
+	// the second call guarantees the entry is stale by then.
+	ttl, staleTTL := 100*time.Millisecond, 99*time.Millisecond
+	v, err := b.GetOrBuildStale(context.Background(), "k", ttl, staleTTL, build)
+	if err != nil {
+		t.Fatalf("GetOrBuildStale: %v", err)
+	}
+	if v != int32(1) {
+		t.Fatalf("first GetOrBuildStale() = %v, want 1", v)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	// The cached entry is now stale, so this call should both return it
+	// immediately and trigger a background rebuild.
+	v, err = b.GetOrBuildStale(context.Background(), "k", ttl, staleTTL, build)
+	if err != nil {
+		t.Fatalf("GetOrBuildStale: %v", err)
+	}
+	if v != int32(1) {
+		t.Fatalf("stale GetOrBuildStale() = %v, want the still-cached value 1", v)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&calls) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("build was called %d times, want a background revalidation to bring it to 2", got)
+	}
+}
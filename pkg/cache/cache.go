@@ -2,17 +2,12 @@
 package cache
 
 import (
+	"container/list"
 	"errors"
 	"sync"
 	"time"
 )
 
-// Package level variables for singleton pattern implementation
-var (
-	instance Cache     // Singleton instance of the cache
-	once     sync.Once // Ensures thread-safe initialization
-)
-
 // Logger interface defines methods for logging operations.
 // This can be implemented for custom logging solutions.
 type Logger interface {
@@ -47,6 +42,23 @@ type Cache interface {
 
 	// RemoveExpiredEntries removes all expired entries from the cache.
 	RemoveExpiredEntries()
+
+	// Close releases any resources held by the cache, such as a background
+	// cleanup goroutine or a network connection, and stops the cache from
+	// being used further.
+	Close() error
+}
+
+// Incrementer is implemented by caches that can atomically increment an
+// integer counter at a key. Callers tracking things like brute-force
+// attempt counts across concurrent requests should prefer this over a
+// plain Get-then-Set, which races under concurrent callers.
+type Incrementer interface {
+	// Increment atomically adds delta to the integer stored at key
+	// (creating it at delta if the key is absent or expired) and returns
+	// the new value. If the key is newly created, expiration (a Unix
+	// timestamp, as in Set) is applied to it.
+	Increment(key string, delta int64, expiration int64) (int64, error)
 }
 
 // CacheEntry represents a single entry in the cache with its data and expiration time.
@@ -60,94 +72,265 @@ func (c CacheEntry) IsExpired() bool {
 	return c.Expiry < time.Now().Unix()
 }
 
-// CacheInstance implements the Cache interface using an in-memory map.
+// EvictionPolicy selects how a CacheInstance chooses an entry to evict once
+// it has grown past Options.MaxEntries.
+type EvictionPolicy string
+
+const (
+	// EvictionLRU evicts the least recently used entry. This is the default.
+	EvictionLRU EvictionPolicy = "LRU"
+	// EvictionLFU evicts the least frequently used entry.
+	EvictionLFU EvictionPolicy = "LFU"
+)
+
+// Options configures a CacheInstance constructed via New.
+type Options struct {
+	// DefaultTTL is available for callers that want a standard expiry
+	// without computing their own Unix timestamp. CacheInstance itself
+	// always honors whatever expiration is passed to Set.
+	DefaultTTL time.Duration
+
+	// CleanupInterval controls how often the janitor goroutine sweeps
+	// expired entries. Defaults to 1 minute.
+	CleanupInterval time.Duration
+
+	// MaxEntries bounds the number of entries the cache holds. Once
+	// exceeded, EvictionPolicy decides which entry to evict. Zero (the
+	// default) means unbounded.
+	MaxEntries int
+
+	// EvictionPolicy selects the eviction strategy used once MaxEntries is
+	// exceeded. Defaults to EvictionLRU.
+	EvictionPolicy EvictionPolicy
+}
+
+// cacheNode is the value stored in CacheInstance.order's list.Element. It
+// carries the entry itself plus the access-frequency count EvictionLFU uses
+// to pick a victim.
+type cacheNode struct {
+	key       string
+	entry     CacheEntry
+	frequency int
+}
+
+// CacheInstance implements the Cache interface using an in-memory map plus a
+// doubly-linked list (container/list) that tracks recency/frequency of
+// access for O(1) Get/Set and O(1) EvictionLRU eviction; EvictionLFU
+// eviction scans the whole list for the least frequently used entry.
 type CacheInstance struct {
-	data map[string]CacheEntry // Internal storage for cache entries
-	mu   sync.RWMutex          // RWMutex for thread-safe operations
+	data    map[string]*list.Element // key -> list node holding *cacheNode
+	order   *list.List               // front = most recently/frequently used
+	mu      sync.RWMutex
+	opts    Options
+	closeCh chan struct{}
+	closed  bool
+}
+
+// New constructs a CacheInstance configured by opts and starts its janitor
+// goroutine. Call Close when done with it to stop that goroutine.
+func New(opts Options) *CacheInstance {
+	if opts.CleanupInterval <= 0 {
+		opts.CleanupInterval = 1 * time.Minute
+	}
+	if opts.EvictionPolicy == "" {
+		opts.EvictionPolicy = EvictionLRU
+	}
+
+	c := &CacheInstance{
+		data:    make(map[string]*list.Element),
+		order:   list.New(),
+		opts:    opts,
+		closeCh: make(chan struct{}),
+	}
+
+	go c.runJanitor()
+	return c
+}
+
+// runJanitor periodically removes expired entries until Close is called.
+func (c *CacheInstance) runJanitor() {
+	ticker := time.NewTicker(c.opts.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.RemoveExpiredEntries()
+		case <-c.closeCh:
+			return
+		}
+	}
 }
 
 // RemoveExpiredEntries removes all expired entries from the cache.
-// This method is called periodically by the cleanup goroutine.
+// This method is called periodically by the janitor goroutine.
 func (c *CacheInstance) RemoveExpiredEntries() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	for key, entry := range c.data {
-		if entry.IsExpired() {
-			delete(c.data, key)
+	for e := c.order.Front(); e != nil; {
+		next := e.Next()
+		node := e.Value.(*cacheNode)
+		if node.entry.IsExpired() {
+			c.removeElement(e)
 		}
+		e = next
 	}
 }
 
-// Set stores a value in the cache with the specified expiration time.
-// Returns an error if the key is empty.
+// Set stores a value in the cache with the specified expiration time,
+// marking the entry as the most recently used, and evicts an entry if
+// MaxEntries is now exceeded. Returns an error if the key is empty.
 func (c *CacheInstance) Set(key string, value any, expiration int64) error {
 	if key == "" {
 		return errors.New("key cannot be empty")
 	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.data[key] = CacheEntry{
-		Data:   value,
-		Expiry: expiration,
+
+	entry := CacheEntry{Data: value, Expiry: expiration}
+
+	if e, exists := c.data[key]; exists {
+		node := e.Value.(*cacheNode)
+		node.entry = entry
+		node.frequency++
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	node := &cacheNode{key: key, entry: entry, frequency: 1}
+	c.data[key] = c.order.PushFront(node)
+
+	if c.opts.MaxEntries > 0 && len(c.data) > c.opts.MaxEntries {
+		c.evict()
 	}
 	return nil
 }
 
-// Get retrieves a value from the cache.
-// Returns the value and true if the key exists and is not expired,
-// otherwise returns nil and false.
+// Get retrieves a value from the cache, marking the entry as the most
+// recently/frequently used. Returns the value and true if the key exists
+// and is not expired, otherwise returns nil and false.
 func (c *CacheInstance) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, exists := c.data[key]
-	if !exists || entry.IsExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, exists := c.data[key]
+	if !exists {
 		return nil, false
 	}
-	return entry.Data, true
+
+	node := e.Value.(*cacheNode)
+	if node.entry.IsExpired() {
+		c.removeElement(e)
+		return nil, false
+	}
+
+	node.frequency++
+	c.order.MoveToFront(e)
+	return node.entry.Data, true
+}
+
+// Increment atomically adds delta to the integer stored at key (creating
+// it at delta if key is absent or expired), under the same lock Get/Set
+// use, and returns the new value.
+func (c *CacheInstance) Increment(key string, delta int64, expiration int64) (int64, error) {
+	if key == "" {
+		return 0, errors.New("key cannot be empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, exists := c.data[key]; exists {
+		node := e.Value.(*cacheNode)
+		current := delta
+		expiry := expiration
+		if !node.entry.IsExpired() {
+			if v, ok := node.entry.Data.(int64); ok {
+				current = v + delta
+				expiry = node.entry.Expiry
+			}
+		}
+		node.entry = CacheEntry{Data: current, Expiry: expiry}
+		node.frequency++
+		c.order.MoveToFront(e)
+		return current, nil
+	}
+
+	current := delta
+	node := &cacheNode{key: key, entry: CacheEntry{Data: current, Expiry: expiration}, frequency: 1}
+	c.data[key] = c.order.PushFront(node)
+
+	if c.opts.MaxEntries > 0 && len(c.data) > c.opts.MaxEntries {
+		c.evict()
+	}
+	return current, nil
 }
 
 // Delete removes an entry from the cache by its key.
 func (c *CacheInstance) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.data, key)
+	if e, exists := c.data[key]; exists {
+		c.removeElement(e)
+	}
 }
 
 // Exists checks if a key exists in the cache and is not expired.
 func (c *CacheInstance) Exists(key string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	_, exists := (c.data)[key]
+	e, exists := c.data[key]
 	if !exists {
 		return false
 	}
-	entry := (c.data)[key]
-	return !entry.IsExpired()
+	return !e.Value.(*cacheNode).entry.IsExpired()
 }
 
 // Clear removes all entries from the cache.
 func (c *CacheInstance) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.data = make(map[string]CacheEntry)
+	c.data = make(map[string]*list.Element)
+	c.order = list.New()
 }
 
-// GetCache returns the singleton instance of the cache.
-// If the instance doesn't exist, it creates one and starts the cleanup routine.
-func GetCache() Cache {
-	once.Do(func() {
-		instance = &CacheInstance{
-			data: make(map[string]CacheEntry),
-			mu:   sync.RWMutex{},
-		}
+// Close stops the janitor goroutine. It is safe to call more than once.
+func (c *CacheInstance) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.closeCh)
+	}
+	return nil
+}
+
+// evict removes one entry according to c.opts.EvictionPolicy. Callers must
+// hold c.mu.
+func (c *CacheInstance) evict() {
+	if c.order.Len() == 0 {
+		return
+	}
 
-		// Start a goroutine to periodically remove expired entries
-		go func() {
-			for {
-				time.Sleep(1 * time.Minute)
-				instance.RemoveExpiredEntries()
+	if c.opts.EvictionPolicy == EvictionLFU {
+		victim := c.order.Front()
+		for e := c.order.Front(); e != nil; e = e.Next() {
+			if e.Value.(*cacheNode).frequency < victim.Value.(*cacheNode).frequency {
+				victim = e
 			}
-		}()
-	})
-	return instance
+		}
+		c.removeElement(victim)
+		return
+	}
+
+	// EvictionLRU (default): the tail is the least recently used entry.
+	c.removeElement(c.order.Back())
+}
+
+// removeElement deletes e from both the map and the list. Callers must hold
+// c.mu.
+func (c *CacheInstance) removeElement(e *list.Element) {
+	node := e.Value.(*cacheNode)
+	delete(c.data, node.key)
+	c.order.Remove(e)
 }
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheInstanceSetGetExpiry(t *testing.T) {
+	c := New(Options{})
+	defer c.Close()
+
+	if err := c.Set("k", "v", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	v, ok := c.Get("k")
+	if !ok || v != "v" {
+		t.Fatalf("Get() = %v, %v, want v, true", v, ok)
+	}
+
+	if err := c.Set("expired", "v", time.Now().Add(-time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, ok := c.Get("expired"); ok {
+		t.Fatal("Get() of an already-expired entry returned true")
+	}
+}
+
+func TestCacheInstanceLRUEviction(t *testing.T) {
+	c := New(Options{MaxEntries: 2, EvictionPolicy: EvictionLRU})
+	defer c.Close()
+
+	future := time.Now().Add(time.Minute).Unix()
+	c.Set("a", 1, future)
+	c.Set("b", 2, future)
+	c.Get("a") // touch a, making b the least recently used
+	c.Set("c", 3, future)
+
+	if c.Exists("b") {
+		t.Fatal("LRU eviction kept the least recently used entry")
+	}
+	if !c.Exists("a") || !c.Exists("c") {
+		t.Fatal("LRU eviction removed an entry it shouldn't have")
+	}
+}
+
+func TestCacheInstanceLFUEviction(t *testing.T) {
+	c := New(Options{MaxEntries: 2, EvictionPolicy: EvictionLFU})
+	defer c.Close()
+
+	future := time.Now().Add(time.Minute).Unix()
+	c.Set("a", 1, future)
+	c.Set("b", 2, future)
+	for i := 0; i < 5; i++ {
+		c.Get("a") // a is accessed far more often than b, so it must survive
+	}
+	c.Set("c", 3, future)
+
+	if !c.Exists("a") {
+		t.Fatal("LFU eviction removed the most frequently used entry")
+	}
+
+	c.mu.RLock()
+	n := len(c.data)
+	c.mu.RUnlock()
+	if n > 2 {
+		t.Fatalf("cache holds %d entries, want at most MaxEntries (2)", n)
+	}
+}
+
+func TestCacheInstanceClose(t *testing.T) {
+	c := New(Options{})
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
+
+func TestCacheInstanceIncrement(t *testing.T) {
+	c := New(Options{})
+	defer c.Close()
+
+	expiry := time.Now().Add(time.Minute).Unix()
+
+	got, err := c.Increment("attempts", 1, expiry)
+	if err != nil || got != 1 {
+		t.Fatalf("Increment() = %d, %v, want 1, nil", got, err)
+	}
+
+	got, err = c.Increment("attempts", 1, expiry)
+	if err != nil || got != 2 {
+		t.Fatalf("Increment() = %d, %v, want 2, nil", got, err)
+	}
+
+	// An expired entry resets the count instead of adding onto a stale value.
+	c.Set("stale", 99, time.Now().Add(-time.Minute).Unix())
+	got, err = c.Increment("stale", 1, expiry)
+	if err != nil || got != 1 {
+		t.Fatalf("Increment() on an expired entry = %d, %v, want 1, nil", got, err)
+	}
+}
+
+func TestCacheInstanceIncrementConcurrent(t *testing.T) {
+	c := New(Options{})
+	defer c.Close()
+
+	const n = 100
+	expiry := time.Now().Add(time.Minute).Unix()
+	done := make(chan struct{})
+	for i := 0; i < n; i++ {
+		go func() {
+			c.Increment("shared", 1, expiry)
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		<-done
+	}
+
+	v, ok := c.Get("shared")
+	if !ok || v.(int64) != n {
+		t.Fatalf("Get() after %d concurrent increments = %v, %v, want %d, true", n, v, ok, n)
+	}
+}
@@ -0,0 +1,50 @@
+package cache
+
+import "time"
+
+// Backend identifies which concrete Cache implementation a Config should
+// resolve to.
+type Backend string
+
+const (
+	// BackendMemory selects the in-memory CacheInstance (the default).
+	BackendMemory Backend = "memory"
+	// BackendRedis selects a Redis-backed cache, suitable for sharing OTP
+	// state across multiple API nodes.
+	BackendRedis Backend = "redis"
+	// BackendMemcached selects a Memcached-backed cache.
+	BackendMemcached Backend = "memcached"
+	// BackendBadger selects an embedded BadgerDB-backed cache.
+	BackendBadger Backend = "badger"
+)
+
+// Config describes how to construct a Cache backend. It is consumed by
+// pkg/cache/providers.New, which dispatches on Backend to build the
+// concrete implementation.
+type Config struct {
+	// Backend selects which Cache implementation to construct. Defaults to
+	// BackendMemory when empty.
+	Backend Backend
+
+	// Addr is the network address of the backing store (e.g. "localhost:6379"
+	// for Redis or "localhost:11211" for Memcached). For BadgerDB, Addr is
+	// instead the on-disk directory path. Unused for BackendMemory.
+	Addr string
+
+	// DB selects the logical database index. Only meaningful for Redis.
+	DB int
+
+	// Password authenticates against the backing store, if required.
+	Password string
+
+	// Namespace is prepended to every key by the Redis, Memcached and
+	// BadgerDB backends so multiple services can share a single backing
+	// store without colliding. BackendMemory does not use it.
+	Namespace string
+
+	// DefaultTTL is used by the Redis, Memcached and BadgerDB backends when
+	// a Set call is given a zero (or already past) expiration. BackendMemory
+	// passes it to Options.DefaultTTL, and its Set still honors the
+	// expiration as given.
+	DefaultTTL time.Duration
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Decode normalizes a value retrieved from Cache.Get (or Increment) into
+// target, a non-nil pointer. Backends that never serialize (the in-memory
+// cache) hand back the original Go value verbatim, so that case is
+// returned as-is. Backends that round-trip values through JSON (Redis,
+// EncryptedCache) hand back a generic map[string]any or float64 instead;
+// re-marshaling whatever was retrieved and unmarshaling it into target
+// normalizes that case through the same path, so callers don't need a
+// bespoke type switch per struct/primitive they store. Returns false if raw
+// is nil or cannot be decoded into target.
+func Decode(raw any, target any) bool {
+	if raw == nil {
+		return false
+	}
+
+	if elem := reflect.ValueOf(target).Elem(); reflect.TypeOf(raw).AssignableTo(elem.Type()) {
+		elem.Set(reflect.ValueOf(raw))
+		return true
+	}
+
+	payload, err := json.Marshal(raw)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(payload, target) == nil
+}
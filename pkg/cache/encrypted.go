@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// SecureCache is implemented by caches that can fail to produce a value for
+// reasons other than an ordinary miss, such as EncryptedCache failing to
+// decrypt or authenticate a stored value. Callers that care about the
+// distinction (e.g. gootp.ValidateOTP) can type-assert a Cache against this
+// interface instead of relying on the plain Get.
+type SecureCache interface {
+	Cache
+
+	// GetSecure retrieves and verifies the value stored under key. It
+	// returns ErrCacheMiss if no entry exists, or ErrDecryptionFailed if an
+	// entry exists but cannot be decrypted/authenticated.
+	GetSecure(key string) (any, error)
+}
+
+// EncryptedCache wraps a Cache and transparently encrypts values with
+// AES-256-GCM before forwarding them to the underlying store (a random
+// nonce is prepended to the ciphertext), so a memory dump or a shared
+// backend such as Redis never exposes a live OTP. Keys are HMAC-SHA256'd
+// before forwarding too, so raw secrets/user identifiers never appear in
+// the underlying store either.
+type EncryptedCache struct {
+	underlying Cache
+	gcm        cipher.AEAD
+	hmacKey    []byte
+}
+
+// NewEncryptedCache wraps underlying so every value passing through it is
+// encrypted at rest. key must be exactly 32 bytes; it is never used
+// directly, but run through HKDF-Expand with distinct info strings to
+// derive independent AES-256 and HMAC-SHA256 subkeys, so a compromise of
+// one primitive's key material doesn't also compromise the other. Use
+// DeriveKey to obtain a suitable key from a passphrase.
+func NewEncryptedCache(underlying Cache, key []byte) (*EncryptedCache, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("cache: encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	aesKey, err := expandSubkey(key, "cryptik-cache-aes-key")
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey, err := expandSubkey(key, "cryptik-cache-hmac-key")
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("cache: failed to initialize AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cache: failed to initialize AES-GCM: %w", err)
+	}
+
+	return &EncryptedCache{
+		underlying: underlying,
+		gcm:        gcm,
+		hmacKey:    hmacKey,
+	}, nil
+}
+
+// expandSubkey derives a 32-byte subkey from key via HKDF-Expand (no
+// extract step; key is already uniformly random), scoped by info so
+// different purposes never end up with the same key material.
+func expandSubkey(key []byte, info string) ([]byte, error) {
+	subkey := make([]byte, 32)
+	kdf := hkdf.Expand(sha256.New, key, []byte(info))
+	if _, err := io.ReadFull(kdf, subkey); err != nil {
+		return nil, fmt.Errorf("cache: failed to derive subkey: %w", err)
+	}
+	return subkey, nil
+}
+
+// DeriveKey derives a 32-byte AES-256 key from passphrase via HKDF-SHA256,
+// suitable for passing to NewEncryptedCache.
+func DeriveKey(passphrase, salt []byte) ([]byte, error) {
+	key := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, passphrase, salt, []byte("cryptik-cache-encryption"))
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, fmt.Errorf("cache: failed to derive key: %w", err)
+	}
+	return key, nil
+}
+
+// hashedKey HMAC-SHA256s key so raw secrets/user identifiers never appear as
+// cache keys in the underlying store.
+func (e *EncryptedCache) hashedKey(key string) string {
+	mac := hmac.New(sha256.New, e.hmacKey)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Set encrypts value with AES-256-GCM and stores it under the HMAC of key.
+func (e *EncryptedCache) Set(key string, value any, expiration int64) error {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cache: failed to serialize value: %w", err)
+	}
+
+	nonce := make([]byte, e.gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("cache: failed to generate nonce: %w", err)
+	}
+
+	ciphertext := e.gcm.Seal(nonce, nonce, plaintext, nil)
+	return e.underlying.Set(e.hashedKey(key), string(ciphertext), expiration)
+}
+
+// Get decrypts and deserializes the value stored under key, treating any
+// decryption failure as a plain miss. Callers that need to distinguish the
+// two should use GetSecure instead.
+func (e *EncryptedCache) Get(key string) (any, bool) {
+	value, err := e.GetSecure(key)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// GetSecure retrieves, decrypts, and deserializes the value stored under
+// key, returning ErrCacheMiss or ErrDecryptionFailed as appropriate.
+func (e *EncryptedCache) GetSecure(key string) (any, error) {
+	stored, exists := e.underlying.Get(e.hashedKey(key))
+	if !exists {
+		return nil, ErrCacheMiss
+	}
+
+	raw, ok := stored.(string)
+	if !ok {
+		return nil, ErrDecryptionFailed
+	}
+	ciphertext := []byte(raw)
+
+	nonceSize := e.gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrDecryptionFailed
+	}
+
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := e.gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	var value any
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return value, nil
+}
+
+// Delete removes the entry stored under the HMAC of key.
+func (e *EncryptedCache) Delete(key string) {
+	e.underlying.Delete(e.hashedKey(key))
+}
+
+// Exists reports whether an entry is stored under the HMAC of key.
+func (e *EncryptedCache) Exists(key string) bool {
+	return e.underlying.Exists(e.hashedKey(key))
+}
+
+// Clear removes every entry from the underlying cache.
+func (e *EncryptedCache) Clear() {
+	e.underlying.Clear()
+}
+
+// RemoveExpiredEntries delegates to the underlying cache.
+func (e *EncryptedCache) RemoveExpiredEntries() {
+	e.underlying.RemoveExpiredEntries()
+}
+
+// Close delegates to the underlying cache.
+func (e *EncryptedCache) Close() error {
+	return e.underlying.Close()
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := DeriveKey([]byte("passphrase"), []byte("salt"))
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	return key
+}
+
+func TestEncryptedCacheRoundTrip(t *testing.T) {
+	underlying := New(Options{})
+	defer underlying.Close()
+
+	enc, err := NewEncryptedCache(underlying, testKey(t))
+	if err != nil {
+		t.Fatalf("NewEncryptedCache: %v", err)
+	}
+
+	if err := enc.Set("otp-key", "123456", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok := enc.Get("otp-key")
+	if !ok || got != "123456" {
+		t.Fatalf("Get() = %v, %v, want 123456, true", got, ok)
+	}
+
+	// The underlying store never sees the plaintext key or value.
+	if underlying.Exists("otp-key") {
+		t.Fatal("plaintext key is present in the underlying store")
+	}
+}
+
+func TestEncryptedCacheTamperDetection(t *testing.T) {
+	underlying := New(Options{})
+	defer underlying.Close()
+
+	enc, err := NewEncryptedCache(underlying, testKey(t))
+	if err != nil {
+		t.Fatalf("NewEncryptedCache: %v", err)
+	}
+
+	if err := enc.Set("otp-key", "123456", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// Corrupt the ciphertext directly in the underlying store.
+	hashed := enc.hashedKey("otp-key")
+	raw, _ := underlying.Get(hashed)
+	tampered := []byte(raw.(string))
+	tampered[len(tampered)-1] ^= 0xFF
+	underlying.Set(hashed, string(tampered), time.Now().Add(time.Minute).Unix())
+
+	if _, err := enc.GetSecure("otp-key"); err != ErrDecryptionFailed {
+		t.Fatalf("GetSecure() on tampered ciphertext = %v, want ErrDecryptionFailed", err)
+	}
+}
+
+func TestEncryptedCacheIndependentSubkeys(t *testing.T) {
+	key := testKey(t)
+	underlying := New(Options{})
+	defer underlying.Close()
+
+	enc, err := NewEncryptedCache(underlying, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedCache: %v", err)
+	}
+
+	// The HMAC key used for hashing cache keys must not be the raw key
+	// passed in: it should be an HKDF subkey distinct from the AES key.
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("some-key"))
+	hashedWithRawKey := hex.EncodeToString(mac.Sum(nil))
+
+	if enc.hashedKey("some-key") == hashedWithRawKey {
+		t.Fatal("EncryptedCache uses the raw encryption key directly as its HMAC key")
+	}
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrCacheMiss is returned by SecureCache.GetSecure when no entry exists
+	// for the given key, mirroring Cache.Get's exists=false.
+	ErrCacheMiss = errors.New("cache: key not found")
+
+	// ErrDecryptionFailed is returned by SecureCache.GetSecure when a stored
+	// value cannot be decrypted or its authenticity cannot be verified, e.g.
+	// because it was encrypted under a different key or tampered with.
+	ErrDecryptionFailed = errors.New("cache: failed to decrypt value")
+)
+
+// OpError wraps a low-level failure (connection loss, serialization, etc.)
+// encountered while a Cache backend performs an operation. Backends that
+// talk to an external store should return one of these from Set instead of
+// a bare error, so callers can distinguish infrastructure failures from
+// ordinary cache misses.
+type OpError struct {
+	Op  string // the Cache method that failed, e.g. "Set"
+	Key string
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return fmt.Sprintf("cache: %s %q: %v", e.Op, e.Key, e.Err)
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"encoding/json"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+// BadgerCache implements cache.Cache on top of an embedded BadgerDB
+// instance, letting a single process run a durable, disk-backed cache
+// without standing up a separate Redis/Memcached server.
+type BadgerCache struct {
+	db         *badger.DB
+	namespace  string
+	defaultTTL time.Duration
+}
+
+// newBadgerCache opens (or creates) a BadgerDB store rooted at cfg.Addr,
+// which is treated as a directory path rather than a network address.
+func newBadgerCache(cfg cache.Config) (*BadgerCache, error) {
+	opts := badger.DefaultOptions(cfg.Addr).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, &cache.OpError{Op: "Open", Err: err}
+	}
+
+	return &BadgerCache{
+		db:         db,
+		namespace:  cfg.Namespace,
+		defaultTTL: cfg.DefaultTTL,
+	}, nil
+}
+
+func (b *BadgerCache) namespacedKey(key string) string {
+	if b.namespace == "" {
+		return key
+	}
+	return b.namespace + ":" + key
+}
+
+// Set serializes value as JSON and stores it under key, expiring at the
+// given Unix timestamp. If expiration is zero (or already in the past), the
+// configured DefaultTTL is used instead.
+func (b *BadgerCache) Set(key string, value any, expiration int64) error {
+	if key == "" {
+		return &cache.OpError{Op: "Set", Key: key, Err: errEmptyKey}
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return &cache.OpError{Op: "Set", Key: key, Err: err}
+	}
+
+	ttl := b.defaultTTL
+	if d := time.Until(time.Unix(expiration, 0)); d > 0 {
+		ttl = d
+	}
+
+	err = b.db.Update(func(txn *badger.Txn) error {
+		entry := badger.NewEntry([]byte(b.namespacedKey(key)), payload)
+		if ttl > 0 {
+			entry = entry.WithTTL(ttl)
+		}
+		return txn.SetEntry(entry)
+	})
+	if err != nil {
+		return &cache.OpError{Op: "Set", Key: key, Err: err}
+	}
+	return nil
+}
+
+// Get retrieves and deserializes the value stored under key.
+func (b *BadgerCache) Get(key string) (any, bool) {
+	var value any
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(b.namespacedKey(key)))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(payload []byte) error {
+			return json.Unmarshal(payload, &value)
+		})
+	})
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Delete removes key from the store.
+func (b *BadgerCache) Delete(key string) {
+	b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(b.namespacedKey(key)))
+	})
+}
+
+// Exists reports whether key is present in the store.
+func (b *BadgerCache) Exists(key string) bool {
+	exists := false
+	b.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(b.namespacedKey(key)))
+		exists = err == nil
+		return nil
+	})
+	return exists
+}
+
+// Clear drops every key, including those outside this cache's namespace.
+func (b *BadgerCache) Clear() {
+	b.db.DropAll()
+}
+
+// RemoveExpiredEntries triggers Badger's value-log garbage collection,
+// which reclaims space held by entries BadgerDB has already expired.
+func (b *BadgerCache) RemoveExpiredEntries() {
+	b.db.RunValueLogGC(0.5)
+}
+
+// Close releases the underlying BadgerDB handle.
+func (b *BadgerCache) Close() error {
+	return b.db.Close()
+}
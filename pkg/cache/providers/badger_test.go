@@ -0,0 +1,132 @@
+package providers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+func newTestBadgerCache(t *testing.T) *BadgerCache {
+	t.Helper()
+	c, err := newBadgerCache(cache.Config{Addr: t.TempDir()})
+	if err != nil {
+		t.Fatalf("newBadgerCache: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestBadgerCacheSetGetDeleteExists(t *testing.T) {
+	c := newTestBadgerCache(t)
+
+	if err := c.Set("k", "v", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	v, ok := c.Get("k")
+	if !ok || v != "v" {
+		t.Fatalf("Get() = %v, %v, want v, true", v, ok)
+	}
+	if !c.Exists("k") {
+		t.Fatal("Exists() = false, want true")
+	}
+
+	c.Delete("k")
+	if c.Exists("k") {
+		t.Fatal("Exists() after Delete = true, want false")
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("Get() after Delete returned true")
+	}
+}
+
+func TestBadgerCacheSetRejectsEmptyKey(t *testing.T) {
+	c := newTestBadgerCache(t)
+
+	if err := c.Set("", "v", time.Now().Add(time.Minute).Unix()); err == nil {
+		t.Fatal("Set() with an empty key = nil error, want one")
+	}
+}
+
+func TestBadgerCacheExpiry(t *testing.T) {
+	c := newTestBadgerCache(t)
+
+	// Badger's TTL has one-second granularity, so anything short of a full
+	// second would round down to "expires immediately".
+	if err := c.Set("expiring", "v", time.Now().Add(2*time.Second).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !c.Exists("expiring") {
+		t.Fatal("Exists() immediately after Set = false, want true")
+	}
+
+	time.Sleep(3 * time.Second)
+
+	if _, ok := c.Get("expiring"); ok {
+		t.Fatal("Get() of an expired entry returned true")
+	}
+	if c.Exists("expiring") {
+		t.Fatal("Exists() of an expired entry returned true")
+	}
+}
+
+func TestBadgerCacheDefaultTTLAppliesToZeroExpiration(t *testing.T) {
+	c, err := newBadgerCache(cache.Config{Addr: t.TempDir(), DefaultTTL: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("newBadgerCache: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Set("k", "v", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !c.Exists("k") {
+		t.Fatal("Exists() immediately after Set with a zero expiration = false, want true")
+	}
+
+	time.Sleep(3 * time.Second)
+
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("Get() past DefaultTTL returned true")
+	}
+}
+
+func TestBadgerCacheNamespacePrefixing(t *testing.T) {
+	c, err := newBadgerCache(cache.Config{Addr: t.TempDir(), Namespace: "ns"})
+	if err != nil {
+		t.Fatalf("newBadgerCache: %v", err)
+	}
+	defer c.Close()
+
+	if got, want := c.namespacedKey("k"), "ns:k"; got != want {
+		t.Fatalf("namespacedKey(%q) = %q, want %q", "k", got, want)
+	}
+
+	if err := c.Set("k", "v", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// The raw, un-namespaced key must not resolve directly against the
+	// underlying store: everything this cache writes goes in under the
+	// "ns:" prefix instead.
+	var raw any
+	err = c.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte("k"))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(payload []byte) error {
+			return json.Unmarshal(payload, &raw)
+		})
+	})
+	if err == nil {
+		t.Fatal("found a value under the bare key, want it stored only under the namespaced key")
+	}
+
+	if !c.Exists("k") {
+		t.Fatal("Exists(\"k\") via the namespaced cache = false, want true")
+	}
+}
@@ -0,0 +1,7 @@
+package providers
+
+import "errors"
+
+// errEmptyKey is returned (wrapped in a cache.OpError) when Set is called
+// with an empty key, mirroring the in-memory CacheInstance's behavior.
+var errEmptyKey = errors.New("key cannot be empty")
@@ -0,0 +1,28 @@
+// Package providers contains concrete cache.Cache implementations backed by
+// external stores (Redis, Memcached, BadgerDB), selected at runtime via
+// cache.Config so an OTP service can be scaled horizontally across multiple
+// API nodes sharing a single backend.
+package providers
+
+import (
+	"fmt"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+// New constructs the Cache implementation selected by cfg.Backend. An empty
+// Backend resolves to the in-memory default.
+func New(cfg cache.Config) (cache.Cache, error) {
+	switch cfg.Backend {
+	case "", cache.BackendMemory:
+		return cache.New(cache.Options{DefaultTTL: cfg.DefaultTTL}), nil
+	case cache.BackendRedis:
+		return newRedisCache(cfg)
+	case cache.BackendMemcached:
+		return newMemcachedCache(cfg)
+	case cache.BackendBadger:
+		return newBadgerCache(cfg)
+	default:
+		return nil, fmt.Errorf("cache: unknown backend %q", cfg.Backend)
+	}
+}
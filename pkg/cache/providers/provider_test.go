@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+func TestNewDefaultsEmptyBackendToMemory(t *testing.T) {
+	c, err := New(cache.Config{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	if _, ok := c.(*cache.CacheInstance); !ok {
+		t.Fatalf("New() with an empty Backend = %T, want *cache.CacheInstance", c)
+	}
+}
+
+func TestNewBackendMemory(t *testing.T) {
+	c, err := New(cache.Config{Backend: cache.BackendMemory})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	if _, ok := c.(*cache.CacheInstance); !ok {
+		t.Fatalf("New() with BackendMemory = %T, want *cache.CacheInstance", c)
+	}
+}
+
+func TestNewBackendBadger(t *testing.T) {
+	c, err := New(cache.Config{Backend: cache.BackendBadger, Addr: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	if _, ok := c.(*BadgerCache); !ok {
+		t.Fatalf("New() with BackendBadger = %T, want *BadgerCache", c)
+	}
+}
+
+func TestNewUnknownBackend(t *testing.T) {
+	_, err := New(cache.Config{Backend: cache.Backend("bogus")})
+	if err == nil {
+		t.Fatal("New() with an unknown backend = nil error, want one")
+	}
+
+	const want = `cache: unknown backend "bogus"`
+	if got := err.Error(); got != want {
+		t.Fatalf("New() error = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+func newTestRedisCache(t *testing.T) *RedisCache {
+	t.Helper()
+	mr := miniredis.RunT(t)
+
+	c, err := newRedisCache(cache.Config{Addr: mr.Addr()})
+	if err != nil {
+		t.Fatalf("newRedisCache: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestRedisCacheSetGetDeleteExists(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	if err := c.Set("k", "v", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	v, ok := c.Get("k")
+	if !ok || v != "v" {
+		t.Fatalf("Get() = %v, %v, want v, true", v, ok)
+	}
+	if !c.Exists("k") {
+		t.Fatal("Exists() = false, want true")
+	}
+
+	c.Delete("k")
+	if c.Exists("k") {
+		t.Fatal("Exists() after Delete = true, want false")
+	}
+}
+
+func TestRedisCacheSetRejectsEmptyKey(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	if err := c.Set("", "v", time.Now().Add(time.Minute).Unix()); err == nil {
+		t.Fatal("Set() with an empty key = nil error, want one")
+	}
+}
+
+func TestRedisCacheNamespacePrefixing(t *testing.T) {
+	c := newTestRedisCache(t)
+	c.namespace = "ns"
+
+	if got, want := c.namespacedKey("k"), "ns:k"; got != want {
+		t.Fatalf("namespacedKey(%q) = %q, want %q", "k", got, want)
+	}
+
+	if err := c.Set("k", "v", time.Now().Add(time.Minute).Unix()); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !c.Exists("k") {
+		t.Fatal("Exists(\"k\") via the namespaced cache = false, want true")
+	}
+}
+
+func TestRedisCacheIncrement(t *testing.T) {
+	c := newTestRedisCache(t)
+	expiry := time.Now().Add(time.Minute).Unix()
+
+	got, err := c.Increment("attempts", 1, expiry)
+	if err != nil || got != 1 {
+		t.Fatalf("Increment() = %d, %v, want 1, nil", got, err)
+	}
+
+	got, err = c.Increment("attempts", 1, expiry)
+	if err != nil || got != 2 {
+		t.Fatalf("Increment() = %d, %v, want 2, nil", got, err)
+	}
+}
+
+func TestRedisCacheIncrementRejectsEmptyKey(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	if _, err := c.Increment("", 1, time.Now().Add(time.Minute).Unix()); err == nil {
+		t.Fatal("Increment() with an empty key = nil error, want one")
+	}
+}
@@ -0,0 +1,172 @@
+package gootp
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+// totpUsedKeyPrefix namespaces the cache entry that records the last
+// redeemed TOTP counter for a key, guarding against replay within the skew
+// window.
+const totpUsedKeyPrefix = "__totp_used:"
+
+// ProvisioningURIer is implemented by OTP services that support QR-code
+// enrollment in authenticator apps. Currently only ModeTOTP does.
+type ProvisioningURIer interface {
+	// ProvisioningURI returns an otpauth://totp/... URL identifying issuer
+	// and account, suitable for encoding into a QR code.
+	ProvisioningURI(issuer, account string) string
+}
+
+// totpServiceInstance implements OTPService using RFC 6238 TOTP, so codes
+// interoperate with standard authenticator apps (Google Authenticator,
+// Authy, ...).
+type totpServiceInstance struct {
+	attemptLimiter
+	Secret []byte
+	Length int
+	Period int
+	Skew   int
+}
+
+// newTOTPService builds an OTPService in ModeTOTP from conf. conf.Secret
+// must be a base32-encoded shared key. conf.Period defaults to 30 seconds
+// and conf.Skew defaults to 1 time-step of tolerance on either side.
+func newTOTPService(conf GoOTPServiceConfig) (OTPService, error) {
+	secret, err := decodeSecret(conf.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	period := conf.Period
+	if period <= 0 {
+		period = 30
+	}
+
+	skew := conf.Skew
+	if skew <= 0 {
+		skew = 1
+	}
+
+	return totpServiceInstance{
+		attemptLimiter: attemptLimiter{
+			CacheService:    conf.Cache,
+			MaxAttempts:     conf.MaxAttempts,
+			LockoutDuration: conf.LockoutDuration,
+		},
+		Secret: secret,
+		Length: conf.Length,
+		Period: period,
+		Skew:   skew,
+	}, nil
+}
+
+// GenerateOTP computes the TOTP value for the current time step. The key
+// parameter is accepted for OTPService compatibility but unused: the moving
+// factor is derived from time, not from any cached state.
+func (t totpServiceInstance) GenerateOTP(key string) (string, error) {
+	return hotpCode(t.Secret, t.timeCounter(time.Now()), t.Length), nil
+}
+
+// ValidateOTP checks otp against every counter within ±Skew steps of the
+// current time, accepting clock drift between client and server. On a
+// match, the counter is recorded in the cache so the same code cannot be
+// replayed again within the window. Consecutive invalid attempts count
+// against MaxAttempts/LockoutDuration like any other OTPService.
+func (t totpServiceInstance) ValidateOTP(key, otp string) (bool, error) {
+	if t.isLockedOut(key) {
+		return false, ErrTooManyAttempts
+	}
+
+	if otp == "" || len(otp) != t.Length {
+		return false, ErrInvalidOTP
+	}
+
+	now := t.timeCounter(time.Now())
+
+	for step := -t.Skew; step <= t.Skew; step++ {
+		counter := uint64(int64(now) + int64(step))
+		if hotpCode(t.Secret, counter, t.Length) != otp {
+			continue
+		}
+
+		used, err := t.wasUsed(key, counter)
+		if err != nil {
+			return false, err
+		}
+		if used {
+			return false, ErrOTPReplayed
+		}
+
+		if err := t.markUsed(key, counter); err != nil {
+			return false, err
+		}
+		t.clearAttempts(key)
+		return true, nil
+	}
+
+	return false, t.recordFailedAttempt(key)
+}
+
+// ProvisioningURI emits an otpauth://totp/... URL for issuer and account,
+// suitable for rendering as a QR code for enrollment in an authenticator
+// app.
+func (t totpServiceInstance) ProvisioningURI(issuer, account string) string {
+	query := url.Values{}
+	query.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(t.Secret))
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(t.Length))
+	query.Set("period", strconv.Itoa(t.Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// timeCounter is the RFC 6238 moving factor: the number of whole Periods
+// that have elapsed since the Unix epoch.
+func (t totpServiceInstance) timeCounter(now time.Time) uint64 {
+	return uint64(now.Unix() / int64(t.Period))
+}
+
+func (t totpServiceInstance) usedCounterKey(key string) string {
+	return totpUsedKeyPrefix + key
+}
+
+// wasUsed reports whether counter has already been redeemed for key. TOTP
+// counters only increase over time, so it is enough to remember the
+// highest one redeemed so far and reject anything at or before it.
+func (t totpServiceInstance) wasUsed(key string, counter uint64) (bool, error) {
+	raw, exists := t.CacheService.Get(t.usedCounterKey(key))
+	if !exists {
+		return false, nil
+	}
+
+	var last uint64
+	if !cache.Decode(raw, &last) {
+		return false, nil
+	}
+	return counter <= last, nil
+}
+
+// markUsed records counter as redeemed for key, expiring once it falls
+// outside any future skew window. counter may be up to Skew steps ahead of
+// now and stays acceptable until Skew steps after that, so the record is
+// kept for 2*Skew+1 Periods.
+func (t totpServiceInstance) markUsed(key string, counter uint64) error {
+	expiry := time.Now().Add(time.Duration(2*t.Skew+1) * time.Duration(t.Period) * time.Second).Unix()
+	if err := t.CacheService.Set(t.usedCounterKey(key), counter, expiry); err != nil {
+		return fmt.Errorf("failed to record used TOTP counter for secret: %s: %w", key, err)
+	}
+	return nil
+}
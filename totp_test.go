@@ -0,0 +1,117 @@
+package gootp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shubhvish4495/cryptik/pkg/cache"
+)
+
+func newTestTOTPService(t *testing.T) OTPService {
+	t.Helper()
+	svc, err := NewService(GoOTPServiceConfig{
+		Mode:   ModeTOTP,
+		Secret: rfc4226Secret,
+		Length: 6,
+		Cache:  cache.New(cache.Options{}),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestTOTPGenerateThenValidate(t *testing.T) {
+	svc := newTestTOTPService(t)
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+
+	ok, err := svc.ValidateOTP("user-1", otp)
+	if err != nil || !ok {
+		t.Fatalf("ValidateOTP() = %v, %v, want true, nil", ok, err)
+	}
+}
+
+func TestTOTPRejectsReplay(t *testing.T) {
+	svc := newTestTOTPService(t)
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+
+	if ok, err := svc.ValidateOTP("user-1", otp); err != nil || !ok {
+		t.Fatalf("first ValidateOTP() = %v, %v, want true, nil", ok, err)
+	}
+
+	if ok, err := svc.ValidateOTP("user-1", otp); ok || err != ErrOTPReplayed {
+		t.Fatalf("replayed ValidateOTP() = %v, %v, want false, ErrOTPReplayed", ok, err)
+	}
+}
+
+func TestTOTPRejectsWrongCode(t *testing.T) {
+	svc := newTestTOTPService(t)
+
+	if ok, err := svc.ValidateOTP("user-1", "000000"); ok || err == nil {
+		t.Fatalf("ValidateOTP() with a wrong code = %v, %v, want false, non-nil error", ok, err)
+	}
+}
+
+func TestTOTPRateLimiting(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{
+		Mode:            ModeTOTP,
+		Secret:          rfc4226Secret,
+		Length:          6,
+		Cache:           cache.New(cache.Options{}),
+		MaxAttempts:     2,
+		LockoutDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	svc.ValidateOTP("user-1", "000000")
+	if _, err := svc.ValidateOTP("user-1", "000000"); err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() at MaxAttempts = %v, want ErrTooManyAttempts", err)
+	}
+
+	otp, err := svc.GenerateOTP("user-1")
+	if err != nil {
+		t.Fatalf("GenerateOTP: %v", err)
+	}
+	if ok, err := svc.ValidateOTP("user-1", otp); ok || err != ErrTooManyAttempts {
+		t.Fatalf("ValidateOTP() with a correct code while locked out = %v, %v, want false, ErrTooManyAttempts", ok, err)
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	svc, err := NewService(GoOTPServiceConfig{
+		Mode:   ModeTOTP,
+		Secret: rfc4226Secret,
+		Length: 6,
+		Cache:  cache.New(cache.Options{}),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	uri, ok := svc.(ProvisioningURIer)
+	if !ok {
+		t.Fatal("ModeTOTP service does not implement ProvisioningURIer")
+	}
+
+	got := uri.ProvisioningURI("ExampleCo", "alice@example.com")
+	if !strings.HasPrefix(got, "otpauth://totp/ExampleCo:alice@example.com?") {
+		t.Fatalf("ProvisioningURI() = %s, want otpauth://totp/... URL", got)
+	}
+	if !strings.Contains(got, "secret=") || !strings.Contains(got, "digits=6") {
+		t.Fatalf("ProvisioningURI() = %s, missing expected query params", got)
+	}
+}